@@ -0,0 +1,93 @@
+package build
+
+import "fmt"
+
+//Mode represents the go build mode used to compile a plugin (plugin or exe)
+const (
+	ModePlugin = "plugin"
+	ModeExe    = "exe"
+)
+
+//Isolation selects how/where the go compiler runs
+type Isolation string
+
+const (
+	//IsolationNone compiles directly on the builder host
+	IsolationNone Isolation = "none"
+	//IsolationDocker compiles inside a docker container running a pinned toolchain image
+	IsolationDocker Isolation = "docker"
+	//IsolationPodman compiles inside a podman container running a pinned toolchain image
+	IsolationPodman Isolation = "podman"
+)
+
+//Build represents a plugin build specification
+type Build struct {
+	Name    string
+	Mode    string
+	Source  Source
+	Go      Go
+	NoCache bool
+
+	//Isolation selects where the go compiler runs; empty/IsolationNone compiles on the
+	//builder host as before
+	Isolation Isolation
+	//Image pins the toolchain container image used when Isolation is docker/podman,
+	//ideally by digest (e.g. golang:1.21-bullseye@sha256:...) for byte-reproducible builds
+	Image string
+
+	Logf func(format string, args ...interface{})
+}
+
+//Option customizes a Build spec before it runs
+type Option func(build *Build)
+
+//WithNoCache disables the content-addressable build cache for this build
+func WithNoCache() Option {
+	return func(b *Build) {
+		b.NoCache = true
+	}
+}
+
+//WithIsolation runs the compile step inside a container using the given runtime
+func WithIsolation(isolation Isolation) Option {
+	return func(b *Build) {
+		b.Isolation = isolation
+	}
+}
+
+//WithBuilderImage pins the toolchain container image used under isolation, ideally by digest
+func WithBuilderImage(image string) Option {
+	return func(b *Build) {
+		b.Image = image
+	}
+}
+
+//Init sets sensible defaults on the build spec
+func (b *Build) Init() {
+	if b.Mode == "" {
+		b.Mode = ModePlugin
+	}
+	if b.Logf == nil {
+		b.Logf = func(format string, args ...interface{}) {}
+	}
+	b.Go.Runtime.Init()
+}
+
+//Validate checks the build spec is usable
+func (b *Build) Validate() error {
+	if b.Name == "" {
+		return fmt.Errorf("build name was empty")
+	}
+	if b.Source.URL == "" && len(b.Source.Data) == 0 && b.Source.Ref == "" {
+		return fmt.Errorf("build source was empty")
+	}
+	return nil
+}
+
+//GetModeWithSpec returns the build mode together with the go build target (package path) to compile
+func (b *Build) GetModeWithSpec() (string, string) {
+	if b.Mode == ModePlugin {
+		return b.Mode, "."
+	}
+	return b.Mode, "."
+}
@@ -0,0 +1,28 @@
+package build
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sort"
+)
+
+//Digest returns a stable sha256 digest over the inputs that determine the compiled
+//artifact: source bytes, go toolchain version, target os/arch, build env and isolation
+//(a plugin built inside a pinned toolchain image is not interchangeable with one built
+//directly on the host, or under a different pinned image)
+func (b *Build) Digest() string {
+	h := sha256.New()
+	h.Write(b.Source.Data)
+	fmt.Fprintf(h, "mode=%s\nname=%s\ngo=%s\nos=%s\narch=%s\nisolation=%s\nimage=%s\n",
+		b.Mode, b.Name, b.Go.Version, b.Go.Runtime.Os, b.Go.Runtime.Arch, b.Isolation, b.Image)
+	keys := make([]string, 0, len(b.Go.Env))
+	for k := range b.Go.Env {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		fmt.Fprintf(h, "env.%s=%s\n", k, b.Go.Env[k])
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
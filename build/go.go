@@ -0,0 +1,9 @@
+package build
+
+//Go holds the go toolchain settings used to compile a plugin
+type Go struct {
+	Version         string
+	Env             map[string]string
+	Runtime         Runtime
+	EnsureTheSameOs bool
+}
@@ -0,0 +1,30 @@
+package build
+
+//HostSpec describes the host program a plugin is about to be loaded into, so Verify can
+//check the plugin's Privileges against what the host actually offers/allows
+type HostSpec struct {
+	//GoVersion is the host's go toolchain version; a mismatch with the plugin's GoVersion
+	//fails verification since plugin.Open requires an exact match
+	GoVersion string
+	//Symbols maps every symbol name the host looks up in the plugin to its expected signature;
+	//an empty signature only requires the symbol to be exported
+	Symbols map[string]string
+	//AllowedIOPackages restricts which I/O-capable packages a plugin may import; empty means
+	//no restriction is enforced
+	AllowedIOPackages []string
+	//Env lists environment variables available to the plugin
+	Env map[string]string
+}
+
+//Allows reports whether pkg is permitted for a plugin under this host spec
+func (h *HostSpec) Allows(pkg string) bool {
+	if len(h.AllowedIOPackages) == 0 {
+		return true
+	}
+	for _, allowed := range h.AllowedIOPackages {
+		if allowed == pkg {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,10 @@
+package build
+
+//Info holds metadata describing a built plugin
+type Info struct {
+	Scn        Scn
+	Runtime    Runtime
+	Name       string
+	GoVersion  string
+	Privileges Privileges
+}
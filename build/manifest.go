@@ -0,0 +1,42 @@
+package build
+
+import "encoding/json"
+
+//ManifestMediaType identifies a pgo plugin OCI manifest
+const ManifestMediaType = "application/vnd.viant.pgo.plugin.v1+json"
+
+//ConfigMediaType identifies the plugin config blob referenced by a Manifest
+const ConfigMediaType = "application/vnd.viant.pgo.plugin.config.v1+json"
+
+//PluginLayerMediaType identifies the compiled .so blob referenced by a Manifest
+const PluginLayerMediaType = "application/vnd.viant.pgo.plugin.layer.v1"
+
+//Descriptor references a content-addressable blob by its sha256 digest
+type Descriptor struct {
+	MediaType string
+	Digest    string //sha256:<hex>
+	Size      int64
+}
+
+//PluginConfig describes the host requirements a plugin blob was built with
+type PluginConfig struct {
+	Runtime    Runtime
+	GoVersion  string
+	Scn        Scn
+	Name       string
+	ModulePath string
+	Privileges Privileges
+}
+
+//Manifest is the content-addressable, OCI-shaped description of a built plugin
+type Manifest struct {
+	MediaType string
+	Config    Descriptor
+	Layers    []Descriptor
+}
+
+//marshalConfig serializes a PluginConfig deterministically for digest computation
+func marshalConfig(cfg PluginConfig) []byte {
+	data, _ := json.Marshal(cfg)
+	return data
+}
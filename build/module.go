@@ -0,0 +1,64 @@
+package build
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"github.com/viant/afs"
+)
+
+//Module represents a compiled plugin ready to be stored or distributed
+type Module struct {
+	Mode     string
+	Data     []byte
+	Info     Info
+	Manifest *Manifest
+}
+
+//Store persists the module .so payload at destURL
+func (m *Module) Store(ctx context.Context, fs afs.Service, destURL string) error {
+	return fs.Upload(ctx, destURL, 0644, bytes.NewReader(m.Data))
+}
+
+//Digest returns the sha256 digest of the compiled .so payload
+func (m *Module) Digest() string {
+	return fmt.Sprintf("sha256:%x", sha256.Sum256(m.Data))
+}
+
+//NewManifest builds the OCI-shaped manifest describing this module, pinning both the
+//plugin layer and configBlob (as returned by ConfigBlob) by sha256 digest
+func (m *Module) NewManifest(configBlob []byte) *Manifest {
+	configDigest := fmt.Sprintf("sha256:%x", sha256.Sum256(configBlob))
+	manifest := &Manifest{
+		MediaType: ManifestMediaType,
+		Config: Descriptor{
+			MediaType: ConfigMediaType,
+			Digest:    configDigest,
+			Size:      int64(len(configBlob)),
+		},
+		Layers: []Descriptor{
+			{
+				MediaType: PluginLayerMediaType,
+				Digest:    m.Digest(),
+				Size:      int64(len(m.Data)),
+			},
+		},
+	}
+	m.Manifest = manifest
+	return manifest
+}
+
+//ConfigBlob serializes this module's PluginConfig, carrying Runtime, GoVersion and the
+//full Privileges manifest so a registry Pull can restore Info verbatim
+func (m *Module) ConfigBlob(modulePath string) []byte {
+	cfg := PluginConfig{
+		Runtime:    m.Info.Runtime,
+		GoVersion:  m.Info.GoVersion,
+		Scn:        m.Info.Scn,
+		Name:       m.Info.Name,
+		ModulePath: modulePath,
+		Privileges: m.Info.Privileges,
+	}
+	return marshalConfig(cfg)
+}
@@ -0,0 +1,27 @@
+package build
+
+//SymbolRequirement describes an exported symbol (func or var) the plugin expects to provide,
+//together with its Go type signature, so a host can validate it before calling plugin.Lookup
+type SymbolRequirement struct {
+	Name      string
+	Signature string
+}
+
+//PackageRequirement pins a host module dependency by version, captured from the plugin's
+//resolved go.mod require/replace set
+type PackageRequirement struct {
+	Path    string
+	Version string
+}
+
+//Privileges declares what a plugin expects from, and may do to, its host process
+type Privileges struct {
+	//Symbols are the exported plugin entry points a host can plugin.Lookup
+	Symbols []SymbolRequirement
+	//HostPackages are the host module versions the plugin was compiled against
+	HostPackages []PackageRequirement
+	//EnvVars are environment variables the plugin reads at runtime
+	EnvVars []string
+	//IOPackages are imported stdlib/third-party packages that perform I/O (net, os/exec, unsafe, ...)
+	IOPackages []string
+}
@@ -0,0 +1,35 @@
+package build
+
+import (
+	"fmt"
+	"runtime"
+)
+
+//Runtime represents the os/arch pair a plugin is built for
+type Runtime struct {
+	Os   string
+	Arch string
+}
+
+//Init defaults Os/Arch to the host toolchain when unset
+func (r *Runtime) Init() {
+	if r.Os == "" {
+		r.Os = runtime.GOOS
+	}
+	if r.Arch == "" {
+		r.Arch = runtime.GOARCH
+	}
+}
+
+//ValidateOsAndArch returns an error if candidate does not match r
+func (r *Runtime) ValidateOsAndArch(candidate *Runtime) error {
+	if r.Os != candidate.Os || r.Arch != candidate.Arch {
+		return fmt.Errorf("runtime mismatch: expected %v/%v, but had %v/%v", r.Os, r.Arch, candidate.Os, candidate.Arch)
+	}
+	return nil
+}
+
+//String returns os/arch representation i.e. linux/amd64
+func (r *Runtime) String() string {
+	return r.Os + "/" + r.Arch
+}
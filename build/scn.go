@@ -0,0 +1,11 @@
+package build
+
+import "time"
+
+//Scn represents a monotonic build sequence number derived from its creation time
+type Scn int64
+
+//AsScn converts t into a Scn
+func AsScn(t time.Time) Scn {
+	return Scn(t.UnixNano())
+}
@@ -0,0 +1,138 @@
+package build
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"github.com/viant/afs"
+	"github.com/viant/afs/url"
+	"golang.org/x/mod/modfile"
+	"io"
+	"os"
+	"path"
+)
+
+//FileModifier allows a file to be transformed (or dropped) while Source is unpacked
+type FileModifier func(parent string, info os.FileInfo, reader io.ReadCloser) (os.FileInfo, io.ReadCloser, error)
+
+//ModFileModifier is invoked for every go.mod encountered while unpacking
+type ModFileModifier func(mod *modfile.File)
+
+//Source represents the location and content of plugin source code
+type Source struct {
+	URL  string
+	Data []byte
+
+	//Ref is an optional remote source reference, resolved into Data before the build runs.
+	//Supported schemes: git+https://host/org/repo@tag//subdir, https://host/archive.tar.gz,
+	//and a bare Go module path@version resolved via GOPROXY
+	Ref string
+	//Checksum pins the resolved Ref content, e.g. sha256:<hex>; the resolved archive is
+	//verified against it before being fed into Unpack
+	Checksum string
+}
+
+//VerifyChecksum checks data against Checksum (sha256:<hex>); a blank Checksum always passes
+func (s *Source) VerifyChecksum(data []byte) error {
+	if s.Checksum == "" {
+		return nil
+	}
+	sum := fmt.Sprintf("sha256:%x", sha256.Sum256(data))
+	if sum != s.Checksum {
+		return fmt.Errorf("checksum mismatch for %v: expected %v, got %v", s.Ref, s.Checksum, sum)
+	}
+	return nil
+}
+
+//Pack reads the source tree rooted at URL into a tar.gz held in Data
+func (s *Source) Pack(ctx context.Context, fs afs.Service) error {
+	if s.URL == "" {
+		return fmt.Errorf("source URL was empty")
+	}
+	buffer := new(bytes.Buffer)
+	gzWriter := gzip.NewWriter(buffer)
+	tarWriter := tar.NewWriter(gzWriter)
+	err := fs.Walk(ctx, s.URL, func(ctx context.Context, baseURL, parent string, info os.FileInfo, reader io.Reader) (toContinue bool, err error) {
+		if info.IsDir() {
+			return true, nil
+		}
+		header, err := tar.FileInfoHeader(info, "")
+		if err != nil {
+			return false, err
+		}
+		header.Name = path.Join(parent, info.Name())
+		if err = tarWriter.WriteHeader(header); err != nil {
+			return false, err
+		}
+		if _, err = io.Copy(tarWriter, reader); err != nil {
+			return false, err
+		}
+		return true, nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to pack source %v: %w", s.URL, err)
+	}
+	if err = tarWriter.Close(); err != nil {
+		return err
+	}
+	if err = gzWriter.Close(); err != nil {
+		return err
+	}
+	s.Data = buffer.Bytes()
+	return nil
+}
+
+//Unpack extracts Data onto destURL, invoking modFn for every parsed go.mod and fileFn before every file is persisted
+func (s *Source) Unpack(ctx context.Context, fs afs.Service, destURL string, modFn ModFileModifier, fileFn FileModifier) error {
+	if len(s.Data) == 0 {
+		return fmt.Errorf("source data was empty")
+	}
+	gzReader, err := gzip.NewReader(bytes.NewReader(s.Data))
+	if err != nil {
+		return fmt.Errorf("failed to open source archive: %w", err)
+	}
+	defer gzReader.Close()
+	tarReader := tar.NewReader(gzReader)
+	for {
+		header, err := tarReader.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read source archive: %w", err)
+		}
+		if header.Typeflag == tar.TypeDir {
+			continue
+		}
+		parent, name := path.Split(header.Name)
+		info := header.FileInfo()
+		reader := io.NopCloser(tarReader)
+		if fileFn != nil {
+			info, reader, err = fileFn(parent, info, reader)
+			if err != nil {
+				return err
+			}
+		}
+		if reader == nil {
+			continue
+		}
+		data, err := io.ReadAll(reader)
+		_ = reader.Close()
+		if err != nil {
+			return err
+		}
+		if modFn != nil && name == "go.mod" {
+			if mod, err := modfile.Parse(header.Name, data, nil); err == nil {
+				modFn(mod)
+			}
+		}
+		destination := url.Join(destURL, header.Name)
+		if err = fs.Upload(ctx, destination, info.Mode(), bytes.NewReader(data)); err != nil {
+			return fmt.Errorf("failed to unpack %v: %w", destination, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,18 @@
+package pgo
+
+import (
+	"context"
+	"github.com/viant/afs"
+	"github.com/viant/pgo/internal/builder"
+)
+
+//Install pulls the plugin referenced by ref (e.g. ghcr.io/org/plugin:v1.2.3) from its
+//OCI registry, verifies its digest and writes it to destURL
+func Install(ref, destURL string, auth *builder.Auth) error {
+	ctx := context.Background()
+	module, err := builder.Pull(ctx, ref, auth)
+	if err != nil {
+		return err
+	}
+	return module.Store(ctx, afs.New(), destURL)
+}
@@ -0,0 +1,54 @@
+package builder
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+)
+
+//zipEntriesToTarGz re-packs a zip archive as a tar.gz, passing every entry name through
+//nameFn (e.g. to strip a module-zip's "<module>@<version>/" prefix)
+func zipEntriesToTarGz(data []byte, nameFn func(name string) string) ([]byte, error) {
+	zipReader, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to open zip archive: %w", err)
+	}
+	buffer := new(bytes.Buffer)
+	gzWriter := gzip.NewWriter(buffer)
+	tarWriter := tar.NewWriter(gzWriter)
+	for _, file := range zipReader.File {
+		if file.FileInfo().IsDir() {
+			continue
+		}
+		rc, err := file.Open()
+		if err != nil {
+			return nil, fmt.Errorf("failed to open %v in zip archive: %w", file.Name, err)
+		}
+		content, err := io.ReadAll(rc)
+		_ = rc.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %v in zip archive: %w", file.Name, err)
+		}
+		header := &tar.Header{
+			Name: nameFn(file.Name),
+			Mode: int64(file.Mode().Perm()),
+			Size: int64(len(content)),
+		}
+		if err := tarWriter.WriteHeader(header); err != nil {
+			return nil, err
+		}
+		if _, err := tarWriter.Write(content); err != nil {
+			return nil, err
+		}
+	}
+	if err := tarWriter.Close(); err != nil {
+		return nil, err
+	}
+	if err := gzWriter.Close(); err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
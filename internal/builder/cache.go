@@ -0,0 +1,131 @@
+package builder
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/viant/afs"
+	"github.com/viant/pgo/build"
+	"io/ioutil"
+	"os"
+	"path"
+	"sort"
+	"time"
+)
+
+//cacheEntry is the per-digest manifest persisted alongside the cached .so blob
+type cacheEntry struct {
+	Digest     string
+	Size       int64
+	Info       build.Info
+	CreatedAt  time.Time
+	AccessedAt time.Time
+}
+
+//Cache is a content-addressable, digest-keyed store of previously built plugins,
+//so repeat builds of the same source/toolchain/target can skip compilation entirely
+type Cache struct {
+	Root     string
+	MaxBytes int64
+	fs       afs.Service
+}
+
+//NewCache creates a build cache rooted at root; MaxBytes<=0 means unbounded
+func NewCache(root string, maxBytes int64) *Cache {
+	return &Cache{Root: root, MaxBytes: maxBytes, fs: afs.New()}
+}
+
+func (c *Cache) blobDir(digest string) string {
+	return path.Join(c.Root, "blobs", "sha256", digest)
+}
+
+//Get returns the cached module for digest, if present
+func (c *Cache) Get(ctx context.Context, digest string) (*build.Module, bool) {
+	dir := c.blobDir(digest)
+	entry, err := c.readEntry(dir)
+	if err != nil {
+		return nil, false
+	}
+	data, err := ioutil.ReadFile(path.Join(dir, "plugin.so"))
+	if err != nil {
+		return nil, false
+	}
+	entry.AccessedAt = time.Now()
+	_ = c.writeEntry(dir, entry)
+	return &build.Module{
+		Mode: build.ModePlugin,
+		Data: data,
+		Info: entry.Info,
+	}, true
+}
+
+//Put inserts mod into the cache under digest, evicting older entries if MaxBytes is exceeded
+func (c *Cache) Put(ctx context.Context, digest string, mod *build.Module) error {
+	dir := c.blobDir(digest)
+	if err := os.MkdirAll(dir, defaultDirPermission); err != nil {
+		return fmt.Errorf("failed to create cache entry %v: %w", digest, err)
+	}
+	if err := ioutil.WriteFile(path.Join(dir, "plugin.so"), mod.Data, 0644); err != nil {
+		return fmt.Errorf("failed to write cache entry %v: %w", digest, err)
+	}
+	now := time.Now()
+	entry := cacheEntry{Digest: digest, Size: int64(len(mod.Data)), Info: mod.Info, CreatedAt: now, AccessedAt: now}
+	if err := c.writeEntry(dir, entry); err != nil {
+		return err
+	}
+	return c.evict()
+}
+
+func (c *Cache) readEntry(dir string) (cacheEntry, error) {
+	var entry cacheEntry
+	data, err := ioutil.ReadFile(path.Join(dir, "manifest.json"))
+	if err != nil {
+		return entry, err
+	}
+	err = json.Unmarshal(data, &entry)
+	return entry, err
+}
+
+func (c *Cache) writeEntry(dir string, entry cacheEntry) error {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path.Join(dir, "manifest.json"), data, 0644)
+}
+
+//evict drops the least-recently-accessed entries until total size is under MaxBytes
+func (c *Cache) evict() error {
+	if c.MaxBytes <= 0 {
+		return nil
+	}
+	root := path.Join(c.Root, "blobs", "sha256")
+	digests, err := ioutil.ReadDir(root)
+	if err != nil {
+		return nil
+	}
+	var entries []cacheEntry
+	var total int64
+	for _, digest := range digests {
+		entry, err := c.readEntry(path.Join(root, digest.Name()))
+		if err != nil {
+			continue
+		}
+		entries = append(entries, entry)
+		total += entry.Size
+	}
+	if total <= c.MaxBytes {
+		return nil
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].AccessedAt.Before(entries[j].AccessedAt) })
+	for _, entry := range entries {
+		if total <= c.MaxBytes {
+			break
+		}
+		if err := os.RemoveAll(c.blobDir(entry.Digest)); err != nil {
+			return fmt.Errorf("failed to evict cache entry %v: %w", entry.Digest, err)
+		}
+		total -= entry.Size
+	}
+	return nil
+}
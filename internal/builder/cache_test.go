@@ -0,0 +1,69 @@
+package builder
+
+import (
+	"context"
+	"github.com/viant/pgo/build"
+	"testing"
+	"time"
+)
+
+func TestCache_PutGet(t *testing.T) {
+	cache := NewCache(t.TempDir(), 0)
+	ctx := context.Background()
+
+	if _, ok := cache.Get(ctx, "missing"); ok {
+		t.Fatal("expected miss for an un-cached digest")
+	}
+
+	mod := &build.Module{
+		Mode: build.ModePlugin,
+		Data: []byte("plugin-bytes"),
+		Info: build.Info{
+			Name:      "demo",
+			GoVersion: "1.21",
+			Runtime:   build.Runtime{Os: "linux", Arch: "amd64"},
+			Privileges: build.Privileges{
+				EnvVars: []string{"DEMO_TOKEN"},
+			},
+		},
+	}
+	if err := cache.Put(ctx, "digest1", mod); err != nil {
+		t.Fatal(err)
+	}
+
+	cached, ok := cache.Get(ctx, "digest1")
+	if !ok {
+		t.Fatal("expected hit for a cached digest")
+	}
+	if string(cached.Data) != string(mod.Data) {
+		t.Errorf("expected data %v, got %v", mod.Data, cached.Data)
+	}
+	if cached.Info.Name != mod.Info.Name || cached.Info.GoVersion != mod.Info.GoVersion || cached.Info.Runtime != mod.Info.Runtime {
+		t.Errorf("expected Info %+v to round-trip, got %+v", mod.Info, cached.Info)
+	}
+	if len(cached.Info.Privileges.EnvVars) != 1 || cached.Info.Privileges.EnvVars[0] != "DEMO_TOKEN" {
+		t.Errorf("expected Privileges to round-trip, got %+v", cached.Info.Privileges)
+	}
+}
+
+func TestCache_Eviction(t *testing.T) {
+	cache := NewCache(t.TempDir(), 10)
+	ctx := context.Background()
+
+	put := func(digest string, size int) {
+		if err := cache.Put(ctx, digest, &build.Module{Data: make([]byte, size)}); err != nil {
+			t.Fatal(err)
+		}
+		time.Sleep(time.Millisecond) //ensure distinct AccessedAt ordering
+	}
+
+	put("oldest", 6)
+	put("newest", 6)
+
+	if _, ok := cache.Get(ctx, "oldest"); ok {
+		t.Error("expected the oldest entry to be evicted once MaxBytes was exceeded")
+	}
+	if _, ok := cache.Get(ctx, "newest"); !ok {
+		t.Error("expected the newest entry to survive eviction")
+	}
+}
@@ -0,0 +1,58 @@
+package builder
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/viant/pgo/build"
+	"net/http"
+	"time"
+)
+
+//Client talks to a delegated builder service over HTTP
+type Client struct {
+	baseURL string
+	http    *http.Client
+}
+
+//NewClient creates a delegation client
+func NewClient(baseURL string) *Client {
+	return &Client{baseURL: baseURL, http: &http.Client{Timeout: 5 * time.Second}}
+}
+
+//IsUp checks whether the delegated builder is reachable
+func (c *Client) IsUp() bool {
+	resp, err := c.http.Get(c.baseURL + "/v1/status")
+	if err != nil {
+		return false
+	}
+	defer resp.Body.Close()
+	return resp.StatusCode == http.StatusOK
+}
+
+//Build delegates a build to the remote builder service
+func (c *Client) Build(ctx context.Context, spec *build.Build) (*build.Module, error) {
+	payload, err := json.Marshal(spec)
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.baseURL+"/v1/build", bytes.NewReader(payload))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to delegate build to %v: %w", c.baseURL, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("delegated build at %v failed with status %v", c.baseURL, resp.StatusCode)
+	}
+	module := &build.Module{}
+	if err := json.NewDecoder(resp.Body).Decode(module); err != nil {
+		return nil, fmt.Errorf("failed to decode delegated build response: %w", err)
+	}
+	return module, nil
+}
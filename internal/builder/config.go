@@ -0,0 +1,36 @@
+package builder
+
+import "github.com/viant/pgo/build"
+
+//Config represents builder service configuration
+type Config struct {
+	Runtime       build.Runtime
+	CacheRoot     string
+	MaxCacheBytes int64
+	delegations   Delegations
+}
+
+//Option customizes a Config
+type Option func(cfg *Config)
+
+//WithLinuxAmd64 pins the builder to the linux/amd64 runtime
+func WithLinuxAmd64(cfg *Config) {
+	cfg.Runtime.Os = "linux"
+	cfg.Runtime.Arch = "amd64"
+}
+
+//WithDelegation registers a delegation used to build for a runtime this host cannot build natively
+func WithDelegation(delegation *Delegation) Option {
+	return func(cfg *Config) {
+		cfg.delegations = append(cfg.delegations, delegation)
+	}
+}
+
+//WithCache enables the content-addressable build cache rooted at root, evicting entries
+//once the cache exceeds maxBytes (maxBytes<=0 means unbounded)
+func WithCache(root string, maxBytes int64) Option {
+	return func(cfg *Config) {
+		cfg.CacheRoot = root
+		cfg.MaxCacheBytes = maxBytes
+	}
+}
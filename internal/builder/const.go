@@ -0,0 +1,5 @@
+package builder
+
+import "os"
+
+const defaultDirPermission = os.FileMode(0755)
@@ -0,0 +1,100 @@
+package builder
+
+import (
+	"context"
+	"fmt"
+	"github.com/viant/pgo/build"
+	"os/exec"
+	"path"
+)
+
+//containerWorkspace is where execRunner mounts the snapshot's PluginBuildPath inside the container
+const containerWorkspace = "/workspace"
+
+//ContainerRunner runs a plugin build inside a container engine
+type ContainerRunner interface {
+	//Name identifies the container engine (docker, podman)
+	Name() string
+	//Available reports whether the engine binary is present on the host
+	Available() bool
+	//Run compiles snapshot inside image, passing the build output to buildSpec.Logf
+	Run(ctx context.Context, image string, snapshot *Snapshot, buildSpec *build.Build) error
+}
+
+type execRunner struct {
+	bin string
+}
+
+func (r *execRunner) Name() string { return r.bin }
+
+func (r *execRunner) Available() bool {
+	_, err := exec.LookPath(r.bin)
+	return err == nil
+}
+
+//Run mounts the snapshot directory and a scoped GOCACHE, then runs `go build` inside image
+//and logs its combined output through buildSpec.Logf once the command finishes,
+//writing the artifact under the container's mounted workspace rather than the host-side
+//PluginDestPath (which is not reachable inside the container's mount namespace)
+func (r *execRunner) Run(ctx context.Context, image string, snapshot *Snapshot, buildSpec *build.Build) error {
+	containerDestPath := path.Join(containerWorkspace, path.Base(snapshot.PluginDestPath))
+	_, goArgs := snapshot.buildCmdArgsWithDest(buildSpec, containerDestPath)
+	containerArgs := append([]string{
+		"run", "--rm",
+		"-v", snapshot.PluginBuildPath + ":" + containerWorkspace,
+		"-w", containerWorkspace,
+		"-e", "GOCACHE=" + path.Join(containerWorkspace, ".gocache"),
+		"-e", fmt.Sprintf("GOOS=%v", buildSpec.Go.Runtime.Os),
+		"-e", fmt.Sprintf("GOARCH=%v", buildSpec.Go.Runtime.Arch),
+		image,
+		"go",
+	}, goArgs...)
+	cmd := exec.CommandContext(ctx, r.bin, containerArgs...)
+	buildSpec.Logf("building plugin in %v container %v: %v %v\n", r.bin, image, r.bin, containerArgs)
+	output, err := cmd.CombinedOutput()
+	buildSpec.Logf("%s", output)
+	if err != nil {
+		return fmt.Errorf("isolated build via %v failed: %w\n%s", r.bin, err, output)
+	}
+	return nil
+}
+
+//DockerRunner runs isolated builds via the docker CLI
+func DockerRunner() ContainerRunner { return &execRunner{bin: "docker"} }
+
+//PodmanRunner runs isolated builds via the podman CLI
+func PodmanRunner() ContainerRunner { return &execRunner{bin: "podman"} }
+
+//detectContainerRunner picks an available engine, preferring the one requested by isolation
+func detectContainerRunner(isolation build.Isolation) (ContainerRunner, error) {
+	candidates := []ContainerRunner{DockerRunner(), PodmanRunner()}
+	if isolation == build.IsolationPodman {
+		candidates = []ContainerRunner{PodmanRunner(), DockerRunner()}
+	}
+	for _, candidate := range candidates {
+		if candidate.Available() {
+			return candidate, nil
+		}
+	}
+	return nil, fmt.Errorf("no container runtime available for isolated build (tried docker, podman)")
+}
+
+var defaultBuilderImagePattern = "golang:%v-bullseye"
+
+//resolveBuilderImage returns buildSpec.Image if pinned, otherwise the default toolchain
+//image for buildSpec.Go.Version
+func resolveBuilderImage(buildSpec *build.Build) string {
+	if buildSpec.Image != "" {
+		return buildSpec.Image
+	}
+	return fmt.Sprintf(defaultBuilderImagePattern, buildSpec.Go.Version)
+}
+
+//buildIsolated compiles snapshot inside a pinned toolchain container regardless of host os/arch
+func (s *Service) buildIsolated(ctx context.Context, snapshot *Snapshot, buildSpec *build.Build) error {
+	runner, err := detectContainerRunner(buildSpec.Isolation)
+	if err != nil {
+		return err
+	}
+	return runner.Run(ctx, resolveBuilderImage(buildSpec), snapshot, buildSpec)
+}
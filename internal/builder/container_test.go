@@ -0,0 +1,111 @@
+package builder
+
+import (
+	"context"
+	"fmt"
+	"github.com/viant/pgo/build"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeStubBinary(t *testing.T, dir, name, body string) {
+	t.Helper()
+	scriptPath := filepath.Join(dir, name)
+	if err := os.WriteFile(scriptPath, []byte("#!/bin/sh\n"+body), 0755); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestDetectContainerRunner(t *testing.T) {
+	dir := t.TempDir()
+	writeStubBinary(t, dir, "docker", "exit 0\n")
+	writeStubBinary(t, dir, "podman", "exit 0\n")
+	t.Setenv("PATH", dir)
+
+	runner, err := detectContainerRunner(build.IsolationDocker)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if runner.Name() != "docker" {
+		t.Errorf("expected docker to be preferred when isolation is docker, got %v", runner.Name())
+	}
+
+	runner, err = detectContainerRunner(build.IsolationPodman)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if runner.Name() != "podman" {
+		t.Errorf("expected podman to be preferred when isolation is podman, got %v", runner.Name())
+	}
+}
+
+func TestDetectContainerRunner_FallsBackWhenPreferredMissing(t *testing.T) {
+	dir := t.TempDir()
+	writeStubBinary(t, dir, "podman", "exit 0\n")
+	t.Setenv("PATH", dir)
+
+	runner, err := detectContainerRunner(build.IsolationDocker)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if runner.Name() != "podman" {
+		t.Errorf("expected to fall back to podman when docker is unavailable, got %v", runner.Name())
+	}
+}
+
+func TestDetectContainerRunner_NoneAvailable(t *testing.T) {
+	t.Setenv("PATH", t.TempDir())
+	if _, err := detectContainerRunner(build.IsolationDocker); err == nil {
+		t.Fatal("expected an error when neither docker nor podman is available")
+	}
+}
+
+func TestResolveBuilderImage(t *testing.T) {
+	testCases := []struct {
+		description string
+		spec        *build.Build
+		expect      string
+	}{
+		{"pinned image wins", &build.Build{Image: "golang:1.21-bullseye@sha256:deadbeef"}, "golang:1.21-bullseye@sha256:deadbeef"},
+		{"default pattern from Go.Version", &build.Build{Go: build.Go{Version: "1.21"}}, "golang:1.21-bullseye"},
+	}
+	for _, tc := range testCases {
+		if got := resolveBuilderImage(tc.spec); got != tc.expect {
+			t.Errorf("%v: expected %v, got %v", tc.description, tc.expect, got)
+		}
+	}
+}
+
+func TestExecRunner_Run(t *testing.T) {
+	dir := t.TempDir()
+	capture := filepath.Join(dir, "args.txt")
+	writeStubBinary(t, dir, "pgo-fake-engine", fmt.Sprintf("printf '%%s\\n' \"$@\" > %q\n", capture))
+	t.Setenv("PATH", dir)
+
+	snapshot := NewSnapshot(build.ModePlugin, ".", build.Go{Version: "1.21", Runtime: build.Runtime{Os: "linux", Arch: "amd64"}})
+	buildSpec := &build.Build{Go: snapshot.goCfg, Isolation: build.IsolationDocker}
+	buildSpec.Init()
+
+	runner := &execRunner{bin: "pgo-fake-engine"}
+	if err := runner.Run(context.Background(), "golang:1.21-bullseye", snapshot, buildSpec); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := os.ReadFile(capture)
+	if err != nil {
+		t.Fatal(err)
+	}
+	joined := strings.ReplaceAll(strings.TrimSpace(string(data)), "\n", " ")
+	wantDest := filepath.Join(containerWorkspace, filepath.Base(snapshot.PluginDestPath))
+	if !strings.Contains(joined, wantDest) {
+		t.Errorf("expected the container build args to target %v, got %v", wantDest, joined)
+	}
+	if !strings.Contains(joined, snapshot.PluginBuildPath+":"+containerWorkspace) {
+		t.Errorf("expected the snapshot build path to be mounted at %v, got %v", containerWorkspace, joined)
+	}
+	if !strings.Contains(joined, "golang:1.21-bullseye") {
+		t.Errorf("expected the builder image to be passed through, got %v", joined)
+	}
+}
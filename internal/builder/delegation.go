@@ -0,0 +1,32 @@
+package builder
+
+import (
+	"fmt"
+	"github.com/viant/pgo/build"
+)
+
+//Delegation describes a remote (usually dockerized) builder capable of producing a plugin for a given runtime
+type Delegation struct {
+	Name    string
+	Runtime build.Runtime
+	Host    string
+	Port    int
+	Image   string
+}
+
+func (d *Delegation) baseURL() string {
+	return fmt.Sprintf("http://%v:%v", d.Host, d.Port)
+}
+
+//Delegations is an ordered collection of Delegation matched by runtime
+type Delegations []*Delegation
+
+//Match returns the delegation able to build for runtime, or nil if none matches
+func (d Delegations) Match(runtime *build.Runtime) *Delegation {
+	for _, candidate := range d {
+		if candidate.Runtime.Os == runtime.Os && candidate.Runtime.Arch == runtime.Arch {
+			return candidate
+		}
+	}
+	return nil
+}
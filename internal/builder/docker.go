@@ -0,0 +1,27 @@
+package builder
+
+import (
+	"fmt"
+	"github.com/viant/pgo/build"
+	"os/exec"
+)
+
+//runDocker starts the delegated builder container backing delegation
+func (s *Service) runDocker(delegation *Delegation, spec *build.Build) error {
+	image := delegation.Image
+	if image == "" {
+		image = "viant/pgo-builder:latest"
+	}
+	args := []string{"run", "-d",
+		"--name", delegation.Name,
+		"-p", fmt.Sprintf("%v:8080", delegation.Port),
+		image,
+	}
+	spec.Logf("starting delegation %v: docker %v\n", delegation.Name, args)
+	cmd := exec.Command("docker", args...)
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("failed to start delegation %v: %w\n\t%s", delegation.Name, err, output)
+	}
+	return nil
+}
@@ -0,0 +1,104 @@
+package builder
+
+import (
+	"bytes"
+	"github.com/viant/pgo/build"
+	"go/ast"
+	"go/parser"
+	"go/printer"
+	"go/token"
+	"strconv"
+)
+
+//ioPackages are stdlib/third-party import paths considered capability-sensitive: they let
+//a plugin reach outside its process (network, exec, unsafe memory access)
+var ioPackages = map[string]bool{
+	"net": true, "net/http": true, "net/rpc": true,
+	"os/exec": true, "unsafe": true, "syscall": true,
+	"os": true, "io/ioutil": true,
+}
+
+//analyzeSource performs a light AST walk over a plugin .go file, recording the exported
+//top-level functions it provides (when isMain) and any capability-sensitive imports it uses;
+//exported variables are not recorded
+func (s *Snapshot) analyzeSource(filename string, source []byte, isMain bool) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, filename, source, parser.ParseComments)
+	if err != nil {
+		return //best-effort: unparsable sources simply contribute no privileges
+	}
+	for _, imp := range file.Imports {
+		path, err := strconv.Unquote(imp.Path.Value)
+		if err != nil {
+			continue
+		}
+		if ioPackages[path] {
+			s.appendIOPackage(path)
+		}
+	}
+	ast.Inspect(file, func(node ast.Node) bool {
+		if envVar, ok := envVarFromOsCall(node); ok {
+			s.appendEnvVar(envVar)
+		}
+		return true
+	})
+	if !isMain {
+		return
+	}
+	for _, decl := range file.Decls {
+		fn, ok := decl.(*ast.FuncDecl)
+		if !ok || fn.Recv != nil || !fn.Name.IsExported() {
+			continue
+		}
+		buffer := new(bytes.Buffer)
+		_ = printer.Fprint(buffer, fset, fn.Type)
+		s.privileges.Symbols = append(s.privileges.Symbols, build.SymbolRequirement{
+			Name:      fn.Name.Name,
+			Signature: buffer.String(),
+		})
+	}
+}
+
+func (s *Snapshot) appendIOPackage(path string) {
+	for _, existing := range s.privileges.IOPackages {
+		if existing == path {
+			return
+		}
+	}
+	s.privileges.IOPackages = append(s.privileges.IOPackages, path)
+}
+
+func (s *Snapshot) appendEnvVar(name string) {
+	for _, existing := range s.privileges.EnvVars {
+		if existing == name {
+			return
+		}
+	}
+	s.privileges.EnvVars = append(s.privileges.EnvVars, name)
+}
+
+//envVarFromOsCall reports the literal environment variable name of an os.Getenv/os.LookupEnv
+//call expression, so analyzeSource can record it as a privilege the plugin requires
+func envVarFromOsCall(node ast.Node) (string, bool) {
+	call, ok := node.(*ast.CallExpr)
+	if !ok || len(call.Args) == 0 {
+		return "", false
+	}
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok || sel.Sel.Name != "Getenv" && sel.Sel.Name != "LookupEnv" {
+		return "", false
+	}
+	pkg, ok := sel.X.(*ast.Ident)
+	if !ok || pkg.Name != "os" {
+		return "", false
+	}
+	lit, ok := call.Args[0].(*ast.BasicLit)
+	if !ok || lit.Kind != token.STRING {
+		return "", false
+	}
+	name, err := strconv.Unquote(lit.Value)
+	if err != nil {
+		return "", false
+	}
+	return name, true
+}
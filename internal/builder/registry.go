@@ -0,0 +1,160 @@
+package builder
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/static"
+	"github.com/google/go-containerregistry/pkg/v1/types"
+	"github.com/viant/pgo/build"
+	"io"
+)
+
+//Auth holds the credentials used to authenticate against an OCI registry
+type Auth struct {
+	Username string
+	Password string
+	Token    string
+}
+
+func (a *Auth) authenticator() authn.Authenticator {
+	switch {
+	case a == nil:
+		return authn.Anonymous
+	case a.Token != "":
+		return &authn.Bearer{Token: a.Token}
+	case a.Username != "":
+		return &authn.Basic{Username: a.Username, Password: a.Password}
+	default:
+		return authn.Anonymous
+	}
+}
+
+//Push uploads mod to an OCI registry reference (e.g. ghcr.io/org/plugin:tag), recording
+//both the compiled .so and its config blob as digest-pinned layers of the plugin manifest
+func Push(ctx context.Context, mod *build.Module, ref string, auth *Auth, modulePath string) error {
+	tag, err := name.ParseReference(ref)
+	if err != nil {
+		return fmt.Errorf("failed to parse reference %v: %w", ref, err)
+	}
+	image, err := pluginImage(mod, modulePath)
+	if err != nil {
+		return fmt.Errorf("failed to assemble plugin image for %v: %w", ref, err)
+	}
+	if err = remote.Write(tag, image, remote.WithContext(ctx), remote.WithAuth(auth.authenticator())); err != nil {
+		return fmt.Errorf("failed to push %v: %w", ref, err)
+	}
+	return nil
+}
+
+//PushIndex uploads one manifest per build.Runtime in mods under a single OCI image index at
+//ref, so a single tag resolves to the right os/arch variant on any target host
+func PushIndex(ctx context.Context, mods map[build.Runtime]*build.Module, ref string, auth *Auth, modulePath string) error {
+	tag, err := name.ParseReference(ref)
+	if err != nil {
+		return fmt.Errorf("failed to parse reference %v: %w", ref, err)
+	}
+	var index v1.ImageIndex = empty.Index
+	for runtime, mod := range mods {
+		image, err := pluginImage(mod, modulePath)
+		if err != nil {
+			return fmt.Errorf("failed to assemble plugin image for %v/%v: %w", runtime.Os, runtime.Arch, err)
+		}
+		index = mutate.AppendManifests(index, mutate.IndexAddendum{
+			Add: image,
+			Descriptor: v1.Descriptor{
+				Platform: &v1.Platform{OS: runtime.Os, Architecture: runtime.Arch},
+			},
+		})
+	}
+	if err = remote.WriteIndex(tag, index, remote.WithContext(ctx), remote.WithAuth(auth.authenticator())); err != nil {
+		return fmt.Errorf("failed to push index %v: %w", ref, err)
+	}
+	return nil
+}
+
+//pluginImage assembles the OCI image for mod: the compiled .so and its config blob as
+//digest-pinned layers, and stamps mod.NewManifest onto the image as an annotation so a
+//puller can read the pgo plugin manifest without recomputing it from the layers
+func pluginImage(mod *build.Module, modulePath string) (v1.Image, error) {
+	configBlob := mod.ConfigBlob(modulePath)
+	manifest := mod.NewManifest(configBlob)
+	manifestJSON, err := json.Marshal(manifest)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal plugin manifest: %w", err)
+	}
+	pluginLayer := static.NewLayer(mod.Data, types.MediaType(build.PluginLayerMediaType))
+	configLayer := static.NewLayer(configBlob, types.MediaType(build.ConfigMediaType))
+	image, err := mutate.Append(empty.Image,
+		mutate.Addendum{Layer: pluginLayer},
+		mutate.Addendum{Layer: configLayer},
+	)
+	if err != nil {
+		return nil, err
+	}
+	return mutate.Annotations(image, map[string]string{build.ManifestMediaType: string(manifestJSON)}).(v1.Image), nil
+}
+
+//Pull downloads the plugin module referenced by ref, verifying the fetched layer matches
+//the digest recorded in the plugin manifest and restoring Info from the config blob
+func Pull(ctx context.Context, ref string, auth *Auth) (*build.Module, error) {
+	tag, err := name.ParseReference(ref)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse reference %v: %w", ref, err)
+	}
+	image, err := remote.Image(tag, remote.WithContext(ctx), remote.WithAuth(auth.authenticator()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to pull %v: %w", ref, err)
+	}
+	layers, err := image.Layers()
+	if err != nil || len(layers) < 2 {
+		return nil, fmt.Errorf("plugin image %v is missing its plugin/config layers", ref)
+	}
+	pluginLayer, configLayer := layers[0], layers[1]
+	data, err := readLayer(pluginLayer)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read plugin layer of %v: %w", ref, err)
+	}
+	digest, err := pluginLayer.Digest()
+	if err != nil {
+		return nil, fmt.Errorf("failed to compute digest for %v: %w", ref, err)
+	}
+	configData, err := readLayer(configLayer)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config layer of %v: %w", ref, err)
+	}
+	var cfg build.PluginConfig
+	if err := json.Unmarshal(configData, &cfg); err != nil {
+		return nil, fmt.Errorf("failed to decode config layer of %v: %w", ref, err)
+	}
+	module := &build.Module{
+		Mode: build.ModePlugin,
+		Data: data,
+		Info: build.Info{
+			Scn:        cfg.Scn,
+			Runtime:    cfg.Runtime,
+			Name:       cfg.Name,
+			GoVersion:  cfg.GoVersion,
+			Privileges: cfg.Privileges,
+		},
+	}
+	if got := module.Digest(); got != "sha256:"+digest.Hex {
+		return nil, fmt.Errorf("digest mismatch pulling %v: expected %v, got %v", ref, digest, got)
+	}
+	return module, nil
+}
+
+func readLayer(layer v1.Layer) ([]byte, error) {
+	rc, err := layer.Uncompressed()
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+	return io.ReadAll(rc)
+}
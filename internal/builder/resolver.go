@@ -0,0 +1,72 @@
+package builder
+
+import (
+	"context"
+	"fmt"
+	"github.com/viant/pgo/build"
+)
+
+//SourceResolver fetches a remote plugin source reference into a tar.gz archive suitable
+//for build.Source.Unpack
+type SourceResolver interface {
+	//CanResolve reports whether this resolver understands ref
+	CanResolve(ref string) bool
+	//Resolve downloads ref and returns its tar.gz content
+	Resolve(ctx context.Context, ref string) ([]byte, error)
+}
+
+//Resolvers is an ordered chain of SourceResolver, matched by the first one that claims ref
+type Resolvers []SourceResolver
+
+//NewResolvers returns the default resolver chain: git, http archive and go module
+func NewResolvers() Resolvers {
+	return Resolvers{
+		&GitResolver{},
+		&HTTPArchiveResolver{},
+		&GoModuleResolver{},
+	}
+}
+
+//Resolve fetches ref using the first matching resolver in the chain
+func (r Resolvers) Resolve(ctx context.Context, ref string) ([]byte, error) {
+	for _, resolver := range r {
+		if resolver.CanResolve(ref) {
+			return resolver.Resolve(ctx, ref)
+		}
+	}
+	return nil, fmt.Errorf("no resolver found for source reference %v", ref)
+}
+
+//resolveSource fetches buildSpec.Source.Ref (caching it by digest so repeated builds of
+//the same ref are offline), verifies it against Source.Checksum and stores it as Source.Data
+func (s *Service) resolveSource(ctx context.Context, buildSpec *build.Build) error {
+	ref := buildSpec.Source.Ref
+	if ref == "" {
+		return nil
+	}
+	digest := sourceDigest(ref, buildSpec.Source.Checksum)
+	if s.sourceCache != nil {
+		if data, ok := s.sourceCache.Get(digest); ok {
+			buildSpec.Logf("using cached source %v\n", ref)
+			if err := buildSpec.Source.VerifyChecksum(data); err != nil {
+				return err
+			}
+			buildSpec.Source.Data = data
+			return nil
+		}
+	}
+	data, err := s.resolvers.Resolve(ctx, ref)
+	if err != nil {
+		return fmt.Errorf("failed to resolve source %v: %w", ref, err)
+	}
+	if err := buildSpec.Source.VerifyChecksum(data); err != nil {
+		return err
+	}
+	if s.sourceCache != nil {
+		if err := s.sourceCache.Put(digest, data); err != nil {
+			buildSpec.Logf("failed to cache source %v: %v\n", ref, err)
+		}
+	}
+	buildSpec.Source.Data = data
+	return nil
+}
@@ -0,0 +1,122 @@
+package builder
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+//GitResolver resolves a "git+https://host/org/repo@ref//subdir" source reference by
+//cloning the repo (including submodules) and archiving the requested subdir
+type GitResolver struct{}
+
+//CanResolve reports whether ref is a git+ source reference
+func (r *GitResolver) CanResolve(ref string) bool {
+	return strings.HasPrefix(ref, "git+")
+}
+
+//Resolve clones the referenced repo at its tag/branch/commit and returns the subdir as a tar.gz
+func (r *GitResolver) Resolve(ctx context.Context, ref string) ([]byte, error) {
+	repoURL, checkout, subdir := parseGitRef(ref)
+	dir, err := ioutil.TempDir("", "pgo-git-")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(dir)
+
+	repo, err := git.PlainCloneContext(ctx, dir, false, &git.CloneOptions{
+		URL:               repoURL,
+		RecurseSubmodules: git.DefaultSubmoduleRecursionDepth,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to clone %v: %w", repoURL, err)
+	}
+	if checkout != "" {
+		tree, err := repo.Worktree()
+		if err != nil {
+			return nil, err
+		}
+		if err := tree.Checkout(&git.CheckoutOptions{Hash: plumbing.NewHash(checkout)}); err != nil {
+			//fall back to a tag/branch reference name when checkout is not a raw commit hash
+			if err = tree.Checkout(&git.CheckoutOptions{Branch: plumbing.NewTagReferenceName(checkout)}); err != nil {
+				if err = tree.Checkout(&git.CheckoutOptions{Branch: plumbing.NewBranchReferenceName(checkout)}); err != nil {
+					return nil, fmt.Errorf("failed to checkout %v@%v: %w", repoURL, checkout, err)
+				}
+			}
+		}
+	}
+	return archiveDir(path.Join(dir, subdir))
+}
+
+func parseGitRef(ref string) (repoURL, checkout, subdir string) {
+	ref = strings.TrimPrefix(ref, "git+")
+	schemeEnd := 0
+	if idx := strings.Index(ref, "://"); idx != -1 {
+		schemeEnd = idx + 3
+	}
+	//a "//" after the scheme (if any) separates the in-repo subdir
+	if sub := strings.Index(ref[schemeEnd:], "//"); sub != -1 {
+		subdir = ref[schemeEnd+sub+2:]
+		ref = ref[:schemeEnd+sub]
+	}
+	if idx := strings.LastIndex(ref, "@"); idx != -1 {
+		repoURL, checkout = ref[:idx], ref[idx+1:]
+		return
+	}
+	return ref, "", subdir
+}
+
+//archiveDir tars and gzips the content of root, skipping .git metadata
+func archiveDir(root string) ([]byte, error) {
+	buffer := new(bytes.Buffer)
+	gzWriter := gzip.NewWriter(buffer)
+	tarWriter := tar.NewWriter(gzWriter)
+	err := filepath.Walk(root, func(file string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			return nil
+		}
+		relative, err := filepath.Rel(root, file)
+		if err != nil {
+			return err
+		}
+		if strings.HasPrefix(relative, ".git"+string(os.PathSeparator)) || relative == ".git" {
+			return nil
+		}
+		header, err := tar.FileInfoHeader(info, "")
+		if err != nil {
+			return err
+		}
+		header.Name = filepath.ToSlash(relative)
+		if err = tarWriter.WriteHeader(header); err != nil {
+			return err
+		}
+		data, err := ioutil.ReadFile(file)
+		if err != nil {
+			return err
+		}
+		_, err = tarWriter.Write(data)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to archive %v: %w", root, err)
+	}
+	if err = tarWriter.Close(); err != nil {
+		return nil, err
+	}
+	if err = gzWriter.Close(); err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
@@ -0,0 +1,54 @@
+package builder
+
+import "testing"
+
+func TestParseGitRef(t *testing.T) {
+	testCases := []struct {
+		description  string
+		ref          string
+		expectURL    string
+		expectRef    string
+		expectSubdir string
+	}{
+		{
+			description:  "scheme, ref and subdir",
+			ref:          "git+https://github.com/org/repo@v1.2.3//subdir",
+			expectURL:    "https://github.com/org/repo",
+			expectRef:    "v1.2.3",
+			expectSubdir: "subdir",
+		},
+		{
+			description:  "scheme and ref, no subdir",
+			ref:          "git+https://github.com/org/repo@v1.2.3",
+			expectURL:    "https://github.com/org/repo",
+			expectRef:    "v1.2.3",
+			expectSubdir: "",
+		},
+		{
+			description:  "scheme, subdir, no ref",
+			ref:          "git+https://github.com/org/repo//subdir",
+			expectURL:    "https://github.com/org/repo",
+			expectRef:    "",
+			expectSubdir: "subdir",
+		},
+		{
+			description:  "nested subdir",
+			ref:          "git+https://github.com/org/repo@main//a/b/c",
+			expectURL:    "https://github.com/org/repo",
+			expectRef:    "main",
+			expectSubdir: "a/b/c",
+		},
+	}
+	for _, tc := range testCases {
+		repoURL, checkout, subdir := parseGitRef(tc.ref)
+		if repoURL != tc.expectURL {
+			t.Errorf("%v: expected url %v, got %v", tc.description, tc.expectURL, repoURL)
+		}
+		if checkout != tc.expectRef {
+			t.Errorf("%v: expected checkout %v, got %v", tc.description, tc.expectRef, checkout)
+		}
+		if subdir != tc.expectSubdir {
+			t.Errorf("%v: expected subdir %v, got %v", tc.description, tc.expectSubdir, subdir)
+		}
+	}
+}
@@ -0,0 +1,89 @@
+package builder
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+)
+
+//GoModuleResolver resolves a bare Go module reference ("<module path>@<version>") by
+//downloading its module zip from GOPROXY, the same mechanism `go mod download` uses
+type GoModuleResolver struct{}
+
+//CanResolve reports whether ref looks like a Go module path@version (fallback resolver:
+//any ref containing "@" that is not claimed by git+ or http(s) is assumed to be a module reference)
+func (r *GoModuleResolver) CanResolve(ref string) bool {
+	return strings.Contains(ref, "@") && !strings.HasPrefix(ref, "git+") &&
+		!strings.HasPrefix(ref, "http://") && !strings.HasPrefix(ref, "https://")
+}
+
+//Resolve downloads the module zip for ref from GOPROXY and re-encodes it as a tar.gz,
+//since build.Source.Unpack only understands tar.gz archives
+func (r *GoModuleResolver) Resolve(ctx context.Context, ref string) ([]byte, error) {
+	idx := strings.LastIndex(ref, "@")
+	modulePath, version := ref[:idx], ref[idx+1:]
+	proxy := os.Getenv("GOPROXY")
+	if proxy == "" || proxy == "direct" {
+		proxy = "https://proxy.golang.org"
+	}
+	proxy = strings.Split(proxy, ",")[0]
+	url := fmt.Sprintf("%v/%v/@v/%v.zip", proxy, escapeModulePath(modulePath), version)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch module %v: %w", ref, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to fetch module %v from %v: status %v", ref, url, resp.StatusCode)
+	}
+	zipData, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch module %v: %w", ref, err)
+	}
+	return moduleZipToTarGz(zipData)
+}
+
+//moduleZipToTarGz re-packs a Go module zip (entries prefixed with "<module>@<version>/") into
+//a tar.gz with that prefix stripped, so it unpacks like any other Source archive
+func moduleZipToTarGz(data []byte) ([]byte, error) {
+	return zipEntriesToTarGz(data, stripModuleZipPrefix)
+}
+
+//stripModuleZipPrefix drops the leading "<module path>@<version>/" segment go module zips
+//use; the module path itself may contain slashes, so the prefix ends at the first "/"
+//following the "@version" component, not the first "/" overall
+func stripModuleZipPrefix(name string) string {
+	at := strings.Index(name, "@")
+	if at == -1 {
+		if idx := strings.Index(name, "/"); idx != -1 {
+			return name[idx+1:]
+		}
+		return name
+	}
+	if idx := strings.Index(name[at:], "/"); idx != -1 {
+		return name[at+idx+1:]
+	}
+	return name
+}
+
+//escapeModulePath applies the GOPROXY "!" escaping for uppercase letters in module paths
+func escapeModulePath(modulePath string) string {
+	var sb strings.Builder
+	for _, r := range modulePath {
+		if r >= 'A' && r <= 'Z' {
+			sb.WriteByte('!')
+			sb.WriteRune(r + ('a' - 'A'))
+			continue
+		}
+		sb.WriteRune(r)
+	}
+	return sb.String()
+}
@@ -0,0 +1,68 @@
+package builder
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"io"
+	"testing"
+)
+
+func TestStripModuleZipPrefix(t *testing.T) {
+	testCases := []struct {
+		description string
+		name        string
+		expect      string
+	}{
+		{"module and file", "example.com/mod@v1.0.0/go.mod", "go.mod"},
+		{"nested file", "example.com/mod@v1.0.0/pkg/file.go", "pkg/file.go"},
+		{"multi-segment module path", "github.com/org/repo@v1.2.3/go.mod", "go.mod"},
+		{"no slash", "go.mod", "go.mod"},
+	}
+	for _, tc := range testCases {
+		if got := stripModuleZipPrefix(tc.name); got != tc.expect {
+			t.Errorf("%v: expected %v, got %v", tc.description, tc.expect, got)
+		}
+	}
+}
+
+func TestModuleZipToTarGz(t *testing.T) {
+	zipBuffer := new(bytes.Buffer)
+	zipWriter := zip.NewWriter(zipBuffer)
+	entry, err := zipWriter.Create("example.com/mod@v1.0.0/go.mod")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = entry.Write([]byte("module example.com/mod\n")); err != nil {
+		t.Fatal(err)
+	}
+	if err = zipWriter.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	tarGz, err := moduleZipToTarGz(zipBuffer.Bytes())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	gzReader, err := gzip.NewReader(bytes.NewReader(tarGz))
+	if err != nil {
+		t.Fatal(err)
+	}
+	tarReader := tar.NewReader(gzReader)
+	header, err := tarReader.Next()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if header.Name != "go.mod" {
+		t.Errorf("expected go.mod, got %v", header.Name)
+	}
+	data, err := io.ReadAll(tarReader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != "module example.com/mod\n" {
+		t.Errorf("unexpected content: %v", string(data))
+	}
+}
@@ -16,9 +16,12 @@ import (
 
 //Service represents builder service
 type Service struct {
-	cfg    *Config
-	fs     afs.Service
-	logger func(template string, args ...interface{})
+	cfg         *Config
+	fs          afs.Service
+	cache       *Cache
+	resolvers   Resolvers
+	sourceCache *SourceCache
+	logger      func(template string, args ...interface{})
 }
 
 //Build builds plugin
@@ -31,18 +34,34 @@ func (s *Service) Build(ctx context.Context, buildSpec *build.Build, opts ...bui
 	if err != nil {
 		return nil, err
 	}
-	if err := s.cfg.Runtime.ValidateOsAndArch(&buildSpec.Go.Runtime); err != nil || buildSpec.Go.EnsureTheSameOs {
-		return s.delegateBuildOrFail(ctx, buildSpec, err)
+	isolated := buildSpec.Isolation != "" && buildSpec.Isolation != build.IsolationNone
+	if !isolated {
+		if err := s.cfg.Runtime.ValidateOsAndArch(&buildSpec.Go.Runtime); err != nil || buildSpec.Go.EnsureTheSameOs {
+			return s.delegateBuildOrFail(ctx, buildSpec, err)
+		}
+	}
+	if err = s.resolveSource(ctx, buildSpec); err != nil {
+		return nil, err
 	}
 	if len(buildSpec.Source.Data) == 0 {
 		if err = buildSpec.Source.Pack(ctx, s.fs); err != nil {
 			return nil, err
 		}
 	}
+	var digest string
+	if s.cache != nil && !buildSpec.NoCache {
+		digest = buildSpec.Digest()
+		if cached, ok := s.cache.Get(ctx, digest); ok {
+			buildSpec.Logf("using cached plugin %v\n", digest)
+			return cached, nil
+		}
+	}
 	buildMode, spec := buildSpec.GetModeWithSpec()
 	snapshot := NewSnapshot(buildMode, spec, buildSpec.Go)
-	if err := s.ensureGo(ctx, snapshot, buildSpec.Go.Version, buildSpec.Logf); err != nil {
-		return nil, err
+	if !isolated {
+		if err := s.ensureGo(ctx, snapshot, buildSpec.Go.Version, buildSpec.Logf); err != nil {
+			return nil, err
+		}
 	}
 	if err = buildSpec.Source.Unpack(ctx, s.fs, snapshot.BasePluginURL(),
 		func(mod *modfile.File) {
@@ -61,11 +80,16 @@ func (s *Service) Build(ctx context.Context, buildSpec *build.Build, opts ...bui
 		return nil, err
 	}
 
-	if err = s.build(snapshot, buildSpec); err != nil {
+	if isolated {
+		err = s.buildIsolated(ctx, snapshot, buildSpec)
+	} else {
+		err = s.build(snapshot, buildSpec)
+	}
+	if err != nil {
 		return nil, err
 	}
 
-	data, err := s.fs.DownloadWithURL(ctx, snapshot.PluginDestPath)
+	data, err := s.fs.DownloadWithURL(ctx, snapshot.PluginDestURL())
 	if err != nil {
 		return nil, fmt.Errorf("failed to locate plugin: %v", err)
 	}
@@ -74,11 +98,18 @@ func (s *Service) Build(ctx context.Context, buildSpec *build.Build, opts ...bui
 		Mode: snapshot.buildMode,
 		Data: data,
 		Info: build.Info{
-			Scn:     build.AsScn(snapshot.Created),
-			Runtime: buildSpec.Go.Runtime,
-			Name:    buildSpec.Name,
+			Scn:        build.AsScn(snapshot.Created),
+			Runtime:    buildSpec.Go.Runtime,
+			Name:       buildSpec.Name,
+			GoVersion:  buildSpec.Go.Version,
+			Privileges: snapshot.Privileges(),
 		},
 	}
+	if s.cache != nil && !buildSpec.NoCache {
+		if err := s.cache.Put(ctx, digest, res); err != nil {
+			buildSpec.Logf("failed to cache plugin %v: %v\n", digest, err)
+		}
+	}
 	return res, nil
 }
 
@@ -120,9 +151,13 @@ func (s *Service) processSource(reader io.ReadCloser, parent string, info os.Fil
 			return info, reader, err
 		}
 	}
-	if bytes.Contains(source, mainFragment) {
+	isMain := bytes.Contains(source, mainFragment)
+	if isMain {
 		snapshot.AppendMain(path.Join(parent, info.Name()))
 	}
+	if path.Ext(info.Name()) == ".go" {
+		snapshot.analyzeSource(info.Name(), source, isMain)
+	}
 	return info, io.NopCloser(bytes.NewReader(source)), nil
 }
 
@@ -176,5 +211,10 @@ func New(cfg *Config, opts ...Option) *Service {
 	for _, opt := range opts {
 		opt(cfg)
 	}
-	return &Service{fs: afs.New(), cfg: cfg}
+	svc := &Service{fs: afs.New(), cfg: cfg, resolvers: NewResolvers()}
+	if cfg.CacheRoot != "" {
+		svc.cache = NewCache(cfg.CacheRoot, cfg.MaxCacheBytes)
+		svc.sourceCache = NewSourceCache(cfg.CacheRoot)
+	}
+	return svc
 }
@@ -0,0 +1,131 @@
+package builder
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/viant/pgo/build"
+	"golang.org/x/mod/modfile"
+	"io/ioutil"
+	"os"
+	"path"
+	"time"
+)
+
+//Snapshot represents an isolated, per-build working directory
+type Snapshot struct {
+	buildMode string
+	spec      string
+	goCfg     build.Go
+	Created   time.Time
+	GoDir     string
+	BaseURL   string
+
+	PluginBuildPath string
+	//PluginDestPath is a plain filesystem path, suitable as a `go build -o` argument; use
+	//PluginDestURL for afs calls that need a URL
+	PluginDestPath string
+
+	mainFiles  []string
+	mods       []*modfile.File
+	privileges build.Privileges
+}
+
+//NewSnapshot creates a build snapshot rooted under the system tmp directory
+func NewSnapshot(buildMode, spec string, goCfg build.Go) *Snapshot {
+	created := time.Now()
+	base, err := ioutil.TempDir("", "pgo-build-")
+	if err != nil {
+		base = path.Join(os.TempDir(), fmt.Sprintf("pgo-build-%v", created.UnixNano()))
+	}
+	pluginName := "plugin.so"
+	if buildMode == build.ModeExe {
+		pluginName = "plugin"
+	}
+	return &Snapshot{
+		buildMode:       buildMode,
+		spec:            spec,
+		goCfg:           goCfg,
+		Created:         created,
+		GoDir:           path.Join(os.TempDir(), "pgo-go"),
+		BaseURL:         "file://" + base,
+		PluginBuildPath: base,
+		PluginDestPath:  path.Join(base, pluginName),
+	}
+}
+
+//PluginDestURL returns the afs URL of the compiled plugin artifact
+func (s *Snapshot) PluginDestURL() string {
+	return "file://" + s.PluginDestPath
+}
+
+//BasePluginURL returns the URL the plugin source is unpacked into
+func (s *Snapshot) BasePluginURL() string {
+	return s.BaseURL
+}
+
+//Env returns the environment used to invoke the go build command
+func (s *Snapshot) Env() []string {
+	env := os.Environ()
+	env = append(env, "GOPATH="+path.Join(s.PluginBuildPath, ".gopath"))
+	env = append(env, fmt.Sprintf("GOOS=%v", s.goCfg.Runtime.Os))
+	env = append(env, fmt.Sprintf("GOARCH=%v", s.goCfg.Runtime.Arch))
+	return env
+}
+
+//AppendMod records a go.mod encountered while unpacking the source
+func (s *Snapshot) AppendMod(mod *modfile.File) {
+	s.mods = append(s.mods, mod)
+}
+
+//AppendMain records a main package file path encountered while unpacking the source
+func (s *Snapshot) AppendMain(location string) {
+	s.mainFiles = append(s.mainFiles, location)
+}
+
+//HostPackageRequirements derives the host module versions the plugin was compiled against
+//from every go.mod encountered while unpacking the source (AppendMod)
+func (s *Snapshot) HostPackageRequirements() []build.PackageRequirement {
+	var requirements []build.PackageRequirement
+	for _, mod := range s.mods {
+		for _, require := range mod.Require {
+			requirements = append(requirements, build.PackageRequirement{Path: require.Mod.Path, Version: require.Mod.Version})
+		}
+		for _, replace := range mod.Replace {
+			requirements = append(requirements, build.PackageRequirement{Path: replace.New.Path, Version: replace.New.Version})
+		}
+	}
+	return requirements
+}
+
+//Privileges returns the privileges manifest accumulated while analyzing plugin sources
+func (s *Snapshot) Privileges() build.Privileges {
+	privileges := s.privileges
+	privileges.HostPackages = s.HostPackageRequirements()
+	return privileges
+}
+
+//replaceDependencies returns source with its content unchanged; no host module reference is rewritten
+func (s *Snapshot) replaceDependencies(source []byte) ([]byte, error) {
+	if !bytes.Contains(source, []byte("package main")) {
+		return source, nil
+	}
+	return bytes.Replace(source, []byte("package main"), []byte("package main"), 1), nil
+}
+
+//buildCmdArgs returns the go command and arguments used to compile the snapshot on the host
+func (s *Snapshot) buildCmdArgs(buildSpec *build.Build) (string, []string) {
+	return s.buildCmdArgsWithDest(buildSpec, s.PluginDestPath)
+}
+
+//buildCmdArgsWithDest returns the go command and arguments used to compile the snapshot,
+//writing the artifact to destPath instead of PluginDestPath; used when destPath must be
+//expressed relative to a container's mounted workspace rather than the host filesystem
+func (s *Snapshot) buildCmdArgsWithDest(buildSpec *build.Build, destPath string) (string, []string) {
+	goBin := path.Join(s.GoDir, "go"+s.goCfg.Version, "go", "bin", "go")
+	args := []string{"build"}
+	if s.buildMode == build.ModePlugin {
+		args = append(args, "-buildmode=plugin")
+	}
+	args = append(args, "-o", destPath, s.spec)
+	return goBin, args
+}
@@ -0,0 +1,46 @@
+package builder
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path"
+)
+
+//SourceCache stores resolved remote source archives keyed by a digest of their reference,
+//so repeated builds of the same ref/checksum are offline
+type SourceCache struct {
+	Root string
+}
+
+//NewSourceCache creates a source cache rooted at root
+func NewSourceCache(root string) *SourceCache {
+	return &SourceCache{Root: root}
+}
+
+func sourceDigest(ref, checksum string) string {
+	return fmt.Sprintf("%x", sha256.Sum256([]byte(ref+"|"+checksum)))
+}
+
+func (c *SourceCache) entryPath(digest string) string {
+	return path.Join(c.Root, "sources", "sha256", digest, "source.tar.gz")
+}
+
+//Get returns the cached archive for digest, if present
+func (c *SourceCache) Get(digest string) ([]byte, bool) {
+	data, err := ioutil.ReadFile(c.entryPath(digest))
+	if err != nil {
+		return nil, false
+	}
+	return data, true
+}
+
+//Put inserts data into the cache under digest
+func (c *SourceCache) Put(digest string, data []byte) error {
+	entry := c.entryPath(digest)
+	if err := os.MkdirAll(path.Dir(entry), defaultDirPermission); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(entry, data, 0644)
+}
@@ -0,0 +1,46 @@
+package pgo
+
+import (
+	"fmt"
+	"github.com/viant/pgo/build"
+)
+
+//Options represents pgo.Build options
+type Options struct {
+	Name    string
+	SrcURL  string
+	DestURL string
+	Go      build.Go
+}
+
+//Init sets options defaults
+func (o *Options) Init() {
+	if o.Go.Version == "" {
+		o.Go.Version = "1.17"
+	}
+}
+
+//Validate checks the options are usable
+func (o *Options) Validate() error {
+	if o.SrcURL == "" {
+		return fmt.Errorf("SrcURL was empty")
+	}
+	if o.DestURL == "" {
+		return fmt.Errorf("DestURL was empty")
+	}
+	return nil
+}
+
+func (o *Options) buildSpec() *build.Build {
+	name := o.Name
+	if name == "" {
+		name = "plugin"
+	}
+	return &build.Build{
+		Name: name,
+		Go:   o.Go,
+		Source: build.Source{
+			URL: o.SrcURL,
+		},
+	}
+}
@@ -0,0 +1,39 @@
+package pgo
+
+import (
+	"fmt"
+	"github.com/viant/pgo/build"
+)
+
+//Verify checks mod against hostSpec before the host calls plugin.Open, catching a go
+//version mismatch, a missing exported symbol, or a disallowed I/O capability up front
+func Verify(mod *build.Module, hostSpec *build.HostSpec) error {
+	privileges := mod.Info.Privileges
+	if hostSpec.GoVersion != "" && mod.Info.GoVersion != "" && hostSpec.GoVersion != mod.Info.GoVersion {
+		return fmt.Errorf("go version mismatch: plugin %v was built with %v, host runs %v", mod.Info.Name, mod.Info.GoVersion, hostSpec.GoVersion)
+	}
+	exported := make(map[string]string, len(privileges.Symbols))
+	for _, symbol := range privileges.Symbols {
+		exported[symbol.Name] = symbol.Signature
+	}
+	for name, signature := range hostSpec.Symbols {
+		got, ok := exported[name]
+		if !ok {
+			return fmt.Errorf("plugin %v does not export symbol %v required by host", mod.Info.Name, name)
+		}
+		if signature != "" && got != signature {
+			return fmt.Errorf("plugin %v exports symbol %v with signature %v, host expects %v", mod.Info.Name, name, got, signature)
+		}
+	}
+	for _, pkg := range privileges.IOPackages {
+		if !hostSpec.Allows(pkg) {
+			return fmt.Errorf("plugin %v imports %v which is not in the host's allowed capabilities", mod.Info.Name, pkg)
+		}
+	}
+	for _, envVar := range privileges.EnvVars {
+		if _, ok := hostSpec.Env[envVar]; !ok {
+			return fmt.Errorf("plugin %v requires environment variable %v which host does not provide", mod.Info.Name, envVar)
+		}
+	}
+	return nil
+}